@@ -0,0 +1,95 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// Job is the work a Scheduler entry runs each time its schedule fires.
+type Job interface {
+	Run(ctx context.Context)
+}
+
+// JobFunc adapts a plain function to the Job interface.
+type JobFunc func(ctx context.Context)
+
+// Run implements Job.
+func (f JobFunc) Run(ctx context.Context) { f(ctx) }
+
+// JobWrapper wraps a Job to add cross-cutting behavior such as recovery,
+// logging, tracing, or an overlap policy.
+type JobWrapper func(Job) Job
+
+// Chain is an ordered sequence of JobWrappers.
+type Chain struct {
+	wrappers []JobWrapper
+}
+
+// NewChain returns a Chain that applies w in order around the Job it wraps,
+// with w[0] outermost.
+func NewChain(w ...JobWrapper) Chain {
+	return Chain{wrappers: w}
+}
+
+// Then wraps j with the Chain's JobWrappers.
+func (c Chain) Then(j Job) Job {
+	for i := len(c.wrappers) - 1; i >= 0; i-- {
+		j = c.wrappers[i](j)
+	}
+	return j
+}
+
+// Recover returns a JobWrapper that recovers a panic from the wrapped Job
+// and reports it to logger instead of crashing the Scheduler's run loop.
+func Recover(logger Logger) JobWrapper {
+	return func(j Job) Job {
+		return JobFunc(func(ctx context.Context) {
+			defer func() {
+				if r := recover(); r != nil {
+					logger.Error(fmt.Errorf("%v", r), "job panicked")
+				}
+			}()
+			j.Run(ctx)
+		})
+	}
+}
+
+// WithSkipIfStillRunning returns a JobWrapper that drops an invocation if
+// the job's previous invocation hasn't returned yet. Each call returns an
+// independent JobWrapper, so a new one is needed per entry.
+func WithSkipIfStillRunning() JobWrapper {
+	var running int32
+	return func(j Job) Job {
+		return JobFunc(func(ctx context.Context) {
+			if !atomic.CompareAndSwapInt32(&running, 0, 1) {
+				return
+			}
+			defer atomic.StoreInt32(&running, 0)
+			j.Run(ctx)
+		})
+	}
+}
+
+// WithDelayIfStillRunning returns a JobWrapper that serializes invocations
+// of the job: a tick arriving while the previous invocation is still
+// running waits for it to finish before starting. Each call returns an
+// independent JobWrapper, so a new one is needed per entry.
+func WithDelayIfStillRunning() JobWrapper {
+	var mu sync.Mutex
+	return func(j Job) Job {
+		return JobFunc(func(ctx context.Context) {
+			mu.Lock()
+			defer mu.Unlock()
+			j.Run(ctx)
+		})
+	}
+}
+
+// WithSingleton ensures at most one invocation of the job is running at a
+// time by skipping overlapping ticks; it's an alias for
+// WithSkipIfStillRunning provided under the more familiar name.
+func WithSingleton() JobWrapper {
+	return WithSkipIfStillRunning()
+}
@@ -0,0 +1,134 @@
+package cron
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// CrontabEntry is one schedule line parsed from a crontab file by ParseFile
+// or LoadCrontab.
+type CrontabEntry struct {
+	Expression Expression
+	// Env holds every "NAME=value" assignment in effect at Line, i.e. every
+	// one that appeared earlier in the file.
+	Env map[string]string
+	// User is the user column of a 7-column /etc/crontab-style line. It's
+	// empty for the traditional 6-column form.
+	User string
+	// Line is the 1-based source line number, for error messages.
+	Line int
+
+	// raw is the trimmed source line, used by CrontabWatcher to recognize
+	// an unchanged entry across reloads.
+	raw string
+}
+
+var (
+	envAssignment = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)=(.*)$`)
+	cronUsername  = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+)
+
+// ParseFile parses a crontab from r. Blank lines and lines starting with
+// "#" are ignored. A "NAME=value" line sets an environment variable that's
+// recorded on every entry parsed after it (value may be quoted with ' or
+// "). Every other non-empty line is a schedule: five cron fields followed
+// by either a command (the traditional 6-column form) or a user name and a
+// command (the 7-column form /etc/crontab uses).
+//
+// Which form a line uses is inferred, since both are just whitespace
+// separated: if the token right after the five fields looks like a bare
+// user name (no "/" or spaces) and something still follows it, it's taken
+// to be the 7-column form. This misreads any 6-column command that itself
+// starts with a bare, unpathed word followed by arguments (e.g.
+// "backup-script --full" looks like user "backup-script" running
+// "--full"); start such commands with a path (e.g. "./backup-script
+// --full") to disambiguate.
+func ParseFile(r io.Reader) ([]CrontabEntry, error) {
+	env := map[string]string{}
+	var entries []CrontabEntry
+
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if m := envAssignment.FindStringSubmatch(line); m != nil {
+			env[m[1]] = strings.Trim(m[2], `"'`)
+			continue
+		}
+
+		entry, err := parseCrontabLine(line, env, lineNo)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// LoadCrontab opens and parses the crontab file at path. See ParseFile for
+// the accepted format.
+func LoadCrontab(path string) ([]CrontabEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseFile(f)
+}
+
+func parseCrontabLine(line string, env map[string]string, lineNo int) (CrontabEntry, error) {
+	var expr Expression
+	var user string
+
+	if strings.HasPrefix(line, "@") {
+		// "@daily /bin/x" and friends have no user column to disambiguate;
+		// hand the whole line to Parse's own macro handling.
+		e, err := Parse(line)
+		if err != nil {
+			return CrontabEntry{}, err
+		}
+		expr = e
+	} else {
+		fields := strings.SplitN(line, " ", 6)
+		if len(fields) != 6 {
+			return CrontabEntry{}, fmt.Errorf("invalid expression: %q", line)
+		}
+		rest := fields[5]
+
+		cmd := rest
+		if restFields := strings.SplitN(rest, " ", 2); len(restFields) == 2 && cronUsername.MatchString(restFields[0]) {
+			user, cmd = restFields[0], restFields[1]
+		}
+
+		e, err := Parse(strings.Join(fields[:5], " ") + " " + cmd)
+		if err != nil {
+			return CrontabEntry{}, err
+		}
+		expr = e
+	}
+
+	envCopy := make(map[string]string, len(env))
+	for k, v := range env {
+		envCopy[k] = v
+	}
+
+	return CrontabEntry{
+		Expression: expr,
+		Env:        envCopy,
+		User:       user,
+		Line:       lineNo,
+		raw:        line,
+	}, nil
+}
@@ -0,0 +1,72 @@
+package cron
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseFile(t *testing.T) {
+	in := `
+# a comment, then a blank line
+
+MAILTO=ops@example.com
+0 2 * * * /bin/backup
+FOO=bar
+0 3 * * * root /bin/system-backup
+@daily /bin/rollup
+`
+	entries, err := ParseFile(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("ParseFile: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+
+	backup := entries[0]
+	if backup.Expression.Command != "/bin/backup" {
+		t.Errorf("entries[0].Expression.Command = %q, want /bin/backup", backup.Expression.Command)
+	}
+	if backup.User != "" {
+		t.Errorf("entries[0].User = %q, want empty (6-column form)", backup.User)
+	}
+	if got := backup.Env["MAILTO"]; got != "ops@example.com" {
+		t.Errorf("entries[0].Env[MAILTO] = %q, want ops@example.com", got)
+	}
+	if _, ok := backup.Env["FOO"]; ok {
+		t.Error("entries[0].Env should not see FOO, which is set after it in the file")
+	}
+
+	sysBackup := entries[1]
+	if sysBackup.User != "root" {
+		t.Errorf("entries[1].User = %q, want root", sysBackup.User)
+	}
+	if sysBackup.Expression.Command != "/bin/system-backup" {
+		t.Errorf("entries[1].Expression.Command = %q, want /bin/system-backup", sysBackup.Expression.Command)
+	}
+	if got := sysBackup.Env["FOO"]; got != "bar" {
+		t.Errorf("entries[1].Env[FOO] = %q, want bar", got)
+	}
+
+	rollup := entries[2]
+	if rollup.Expression.Command != "/bin/rollup" {
+		t.Errorf("entries[2].Expression.Command = %q, want /bin/rollup", rollup.Expression.Command)
+	}
+	if rollup.Line != 8 {
+		t.Errorf("entries[2].Line = %d, want 8", rollup.Line)
+	}
+}
+
+func TestParseFileErrorIncludesLineNumber(t *testing.T) {
+	in := "0 2 * * * /bin/ok\n0 99 * * * /bin/bad\n"
+	_, err := ParseFile(strings.NewReader(in))
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("err = %v, want it to mention line 2", err)
+	}
+	if !strings.Contains(err.Error(), "outside of range: 0-23") {
+		t.Errorf("err = %v, want the underlying field error too", err)
+	}
+}
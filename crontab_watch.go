@@ -0,0 +1,141 @@
+package cron
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// CrontabWatcher keeps a Scheduler's entries in sync with a crontab file on
+// disk, reloading and diffing against it whenever the file changes.
+type CrontabWatcher struct {
+	path      string
+	scheduler *Scheduler
+	newJob    func(CrontabEntry) Job
+
+	fsw *fsnotify.Watcher
+
+	mu     sync.Mutex
+	active map[string]JobID // raw line + occurrence ordinal -> the JobID scheduled for it
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// WatchCrontab loads the crontab at path into s, then watches path (via
+// fsnotify) and keeps s's entries in sync on every change: lines added to
+// the file are scheduled, lines removed are unscheduled with
+// Scheduler.Remove, and lines that didn't change are left running
+// undisturbed. newJob turns a parsed CrontabEntry into the Job s runs for
+// it. The returned CrontabWatcher's Close stops watching; it does not stop
+// s or remove the entries it scheduled.
+func WatchCrontab(path string, s *Scheduler, newJob func(CrontabEntry) Job) (*CrontabWatcher, error) {
+	w := &CrontabWatcher{
+		path:      path,
+		scheduler: s,
+		newJob:    newJob,
+		active:    make(map[string]JobID),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+
+	if err := w.reload(); err != nil {
+		return nil, err
+	}
+
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	// Watch the containing directory rather than the file itself: editors
+	// commonly replace a file (write a temp file, then rename it over the
+	// original), which leaves a direct watch on the old inode pointed at
+	// nothing.
+	if err := fsw.Add(filepath.Dir(path)); err != nil {
+		fsw.Close()
+		return nil, err
+	}
+	w.fsw = fsw
+
+	go w.run()
+	return w, nil
+}
+
+func (w *CrontabWatcher) run() {
+	defer close(w.done)
+	for {
+		select {
+		case <-w.stop:
+			return
+		case ev, ok := <-w.fsw.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(ev.Name) != filepath.Clean(w.path) {
+				continue
+			}
+			if ev.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+				continue
+			}
+			if err := w.reload(); err != nil {
+				w.scheduler.logger.Error(err, "reloading crontab", "path", w.path)
+			}
+		case err, ok := <-w.fsw.Errors:
+			if !ok {
+				return
+			}
+			w.scheduler.logger.Error(err, "watching crontab", "path", w.path)
+		}
+	}
+}
+
+// reload re-parses the crontab and diffs it against w.active: new lines
+// are scheduled, removed lines are unscheduled, and unchanged lines (same
+// raw text, same occurrence of that text in the file) are left alone so an
+// in-flight invocation isn't disturbed. The occurrence count is part of the
+// key so that duplicate lines are tracked as distinct entries rather than
+// collapsing into one.
+func (w *CrontabWatcher) reload() error {
+	entries, err := LoadCrontab(w.path)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	seen := make(map[string]bool, len(entries))
+	occurrence := make(map[string]int, len(entries))
+	for _, e := range entries {
+		key := fmt.Sprintf("%s\x00%d", e.raw, occurrence[e.raw])
+		occurrence[e.raw]++
+
+		seen[key] = true
+		if _, ok := w.active[key]; ok {
+			continue
+		}
+		id, err := w.scheduler.addExpression(e.Expression, w.newJob(e))
+		if err != nil {
+			return fmt.Errorf("line %d: %w", e.Line, err)
+		}
+		w.active[key] = id
+	}
+	for key, id := range w.active {
+		if !seen[key] {
+			w.scheduler.Remove(id)
+			delete(w.active, key)
+		}
+	}
+	return nil
+}
+
+// Close stops watching the crontab file. It does not remove the entries it
+// scheduled from the Scheduler.
+func (w *CrontabWatcher) Close() error {
+	close(w.stop)
+	err := w.fsw.Close()
+	<-w.done
+	return err
+}
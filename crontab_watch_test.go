@@ -0,0 +1,87 @@
+package cron
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWatchCrontabReloadsOnChange(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "crontab")
+	if err := os.WriteFile(path, []byte("@every 15ms /bin/a\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	s := NewScheduler()
+	var fired int32
+	w, err := WatchCrontab(path, s, func(e CrontabEntry) Job {
+		return JobFunc(func(ctx context.Context) {
+			atomic.AddInt32(&fired, 1)
+		})
+	})
+	if err != nil {
+		t.Fatalf("WatchCrontab: %v", err)
+	}
+	defer w.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.Start(ctx)
+	defer s.Stop()
+
+	time.Sleep(60 * time.Millisecond)
+	if atomic.LoadInt32(&fired) == 0 {
+		t.Fatal("job from the initial crontab load never fired")
+	}
+	if got := len(s.Entries()); got != 1 {
+		t.Fatalf("Entries() = %d, want 1", got)
+	}
+
+	if err := os.WriteFile(path, []byte(""), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) && len(s.Entries()) != 0 {
+		time.Sleep(20 * time.Millisecond)
+	}
+	if got := len(s.Entries()); got != 0 {
+		t.Fatalf("Entries() = %d after removing the line, want 0", got)
+	}
+}
+
+func TestWatchCrontabDuplicateLines(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "crontab")
+	line := "@every 15ms /bin/a\n"
+	if err := os.WriteFile(path, []byte(line+line), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	s := NewScheduler()
+	w, err := WatchCrontab(path, s, func(e CrontabEntry) Job {
+		return JobFunc(func(ctx context.Context) {})
+	})
+	if err != nil {
+		t.Fatalf("WatchCrontab: %v", err)
+	}
+	defer w.Close()
+
+	if got := len(s.Entries()); got != 2 {
+		t.Fatalf("Entries() = %d for two identical lines, want 2", got)
+	}
+
+	if err := os.WriteFile(path, []byte(line), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) && len(s.Entries()) != 1 {
+		time.Sleep(20 * time.Millisecond)
+	}
+	if got := len(s.Entries()); got != 1 {
+		t.Fatalf("Entries() = %d after removing one duplicate line, want 1", got)
+	}
+}
@@ -0,0 +1,21 @@
+package cron
+
+import "log"
+
+// Logger receives diagnostics from a Scheduler, primarily panics recovered
+// from jobs so they don't take down the run loop silently.
+type Logger interface {
+	Error(err error, msg string, keysAndValues ...interface{})
+}
+
+// DefaultLogger logs to the standard library's log package. It's the
+// Logger NewScheduler uses when WithLogger isn't supplied.
+type DefaultLogger struct {
+	*log.Logger
+}
+
+// Error implements Logger.
+func (l DefaultLogger) Error(err error, msg string, keysAndValues ...interface{}) {
+	args := append([]interface{}{msg, "error:", err}, keysAndValues...)
+	l.Println(args...)
+}
@@ -0,0 +1,191 @@
+package cron
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseMacros(t *testing.T) {
+	cases := []struct {
+		in   string
+		want Expression
+		err  string
+	}{
+		{
+			in: "@yearly /bin/test",
+			want: Expression{
+				Minute:     []uint8{0},
+				Hour:       []uint8{0},
+				DayOfMonth: []uint8{1},
+				Month:      []uint8{1},
+				DayOfWeek:  []uint8{1, 2, 3, 4, 5, 6, 7},
+				Command:    "/bin/test",
+			},
+		},
+		{
+			in: "@annually /bin/test",
+			want: Expression{
+				Minute:     []uint8{0},
+				Hour:       []uint8{0},
+				DayOfMonth: []uint8{1},
+				Month:      []uint8{1},
+				DayOfWeek:  []uint8{1, 2, 3, 4, 5, 6, 7},
+				Command:    "/bin/test",
+			},
+		},
+		{
+			in: "@monthly /bin/test",
+			want: Expression{
+				Minute: []uint8{0},
+				Hour:   []uint8{0},
+				DayOfMonth: []uint8{
+					1,
+				},
+				Month: []uint8{
+					1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12,
+				},
+				DayOfWeek: []uint8{1, 2, 3, 4, 5, 6, 7},
+				Command:   "/bin/test",
+			},
+		},
+		{
+			in: "@weekly /bin/test",
+			want: Expression{
+				Minute: []uint8{0},
+				Hour:   []uint8{0},
+				DayOfMonth: []uint8{
+					1, 2, 3, 4, 5, 6, 7, 8, 9, 10,
+					11, 12, 13, 14, 15, 16, 17, 18, 19, 20,
+					21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31,
+				},
+				Month: []uint8{
+					1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12,
+				},
+				DayOfWeek: []uint8{0},
+				Command:   "/bin/test",
+			},
+		},
+		{
+			in: "@daily /bin/test",
+			want: Expression{
+				Minute: []uint8{0},
+				Hour:   []uint8{0},
+				DayOfMonth: []uint8{
+					1, 2, 3, 4, 5, 6, 7, 8, 9, 10,
+					11, 12, 13, 14, 15, 16, 17, 18, 19, 20,
+					21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31,
+				},
+				Month: []uint8{
+					1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12,
+				},
+				DayOfWeek: []uint8{1, 2, 3, 4, 5, 6, 7},
+				Command:   "/bin/test",
+			},
+		},
+		{
+			in: "@midnight /bin/test",
+			want: Expression{
+				Minute: []uint8{0},
+				Hour:   []uint8{0},
+				DayOfMonth: []uint8{
+					1, 2, 3, 4, 5, 6, 7, 8, 9, 10,
+					11, 12, 13, 14, 15, 16, 17, 18, 19, 20,
+					21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31,
+				},
+				Month: []uint8{
+					1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12,
+				},
+				DayOfWeek: []uint8{1, 2, 3, 4, 5, 6, 7},
+				Command:   "/bin/test",
+			},
+		},
+		{
+			in: "@hourly /bin/test",
+			want: Expression{
+				Minute: []uint8{0},
+				Hour: []uint8{
+					0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10,
+					11, 12, 13, 14, 15, 16, 17, 18, 19, 20,
+					21, 22, 23,
+				},
+				DayOfMonth: []uint8{
+					1, 2, 3, 4, 5, 6, 7, 8, 9, 10,
+					11, 12, 13, 14, 15, 16, 17, 18, 19, 20,
+					21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31,
+				},
+				Month: []uint8{
+					1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12,
+				},
+				DayOfWeek: []uint8{1, 2, 3, 4, 5, 6, 7},
+				Command:   "/bin/test",
+			},
+		},
+		{
+			in:  "@unknown /bin/test",
+			err: "unknown macro",
+		},
+		{
+			in:  "@every notaduration /bin/test",
+			err: "invalid duration",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := Parse(tc.in)
+			if tc.err == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.err != "" {
+				if err == nil {
+					t.Fatal("expected error")
+				} else if !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("err got: %v, want %v", err, tc.err)
+				}
+				return
+			}
+
+			if diff := cmp.Diff(got.String(), tc.want.String()); diff != "" {
+				t.Errorf("(-got +want)\n:%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseEvery(t *testing.T) {
+	e, err := Parse("@every 1h30m /bin/backup")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if e.Every != 90*time.Minute {
+		t.Fatalf("Every = %v, want 1h30m", e.Every)
+	}
+	if e.Command != "/bin/backup" {
+		t.Fatalf("Command = %q, want /bin/backup", e.Command)
+	}
+
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	want := from.Add(90 * time.Minute)
+	if got := e.Next(from); !got.Equal(want) {
+		t.Fatalf("Next(%v) = %v, want %v", from, got, want)
+	}
+}
+
+func TestParseReboot(t *testing.T) {
+	e, err := Parse("@reboot /bin/startup")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if !e.RunAtStartup {
+		t.Fatal("RunAtStartup = false, want true")
+	}
+	if e.Command != "/bin/startup" {
+		t.Fatalf("Command = %q, want /bin/startup", e.Command)
+	}
+	if got := e.Next(time.Now()); !got.IsZero() {
+		t.Fatalf("Next() = %v, want zero Time", got)
+	}
+}
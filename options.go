@@ -0,0 +1,224 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParserOptions enables Quartz-style syntax that Parse/ParseInLocation don't
+// accept, for callers who opt into ParseWithOptions or
+// ParseInLocationWithOptions. The plain 5-field Vixie-cron syntax always
+// remains the default.
+type ParserOptions struct {
+	// Seconds adds a leading seconds field (0-59), shifting minute, hour,
+	// day of month, month and day of week one position to the right.
+	Seconds bool
+	// Year adds a trailing year field after day of week. When Year is set,
+	// every expression must include the field ("*" for any year).
+	Year bool
+}
+
+// ParseWithOptions is like Parse but accepts the Quartz-style syntax
+// enabled by opts: a leading seconds field and trailing year field when
+// requested, and, on DayOfMonth/DayOfWeek, "?" (no specific value,
+// mutually exclusive with the other day field), "L" (last day of the
+// month, or last <weekday> of the month), "W" (nearest weekday to a given
+// day of month) and "#" (n-th <weekday> of the month).
+func ParseWithOptions(s string, opts ParserOptions) (Expression, error) {
+	return ParseInLocationWithOptions(s, time.Local, opts)
+}
+
+// ParseInLocationWithOptions is ParseWithOptions evaluated in loc.
+func ParseInLocationWithOptions(s string, loc *time.Location, opts ParserOptions) (Expression, error) {
+	if strings.HasPrefix(s, "@") {
+		return parseMacro(s, loc)
+	}
+
+	n := 5
+	if opts.Seconds {
+		n++
+	}
+	if opts.Year {
+		n++
+	}
+	parts := strings.SplitN(s, " ", n+1)
+	if len(parts) != n+1 {
+		return Expression{}, errors.New("invalid expression")
+	}
+
+	i := 0
+	next := func() string {
+		p := parts[i]
+		i++
+		return p
+	}
+
+	var second []uint8
+	if opts.Seconds {
+		v, err := expand(next(), minute, 0, 59) // field id only affects name lookups, which seconds doesn't use
+		if err != nil {
+			return Expression{}, fmt.Errorf("second: %w", err)
+		}
+		second = v
+	}
+
+	minuteVal, err := expand(next(), minute, 0, 59)
+	if err != nil {
+		return Expression{}, fmt.Errorf("minute: %w", err)
+	}
+	hourVal, err := expand(next(), hour, 0, 23)
+	if err != nil {
+		return Expression{}, fmt.Errorf("hour: %w", err)
+	}
+
+	domRaw := next()
+	monthVal, err := expand(next(), month, 1, 12)
+	if err != nil {
+		return Expression{}, fmt.Errorf("month: %w", err)
+	}
+	dowRaw := next()
+
+	domVal, domLast, domNearest, domQuestion, err := parseDayOfMonthField(domRaw)
+	if err != nil {
+		return Expression{}, fmt.Errorf("day of month: %w", err)
+	}
+	dowVal, dowLast, dowNth, dowQuestion, err := parseDayOfWeekField(dowRaw)
+	if err != nil {
+		return Expression{}, fmt.Errorf("day of week: %w", err)
+	}
+	if domQuestion && dowQuestion {
+		return Expression{}, errors.New("day of month and day of week cannot both be ?")
+	}
+
+	var year []uint16
+	if opts.Year {
+		v, err := expandYear(next())
+		if err != nil {
+			return Expression{}, fmt.Errorf("year: %w", err)
+		}
+		year = v
+	}
+
+	return Expression{
+		Second:                   second,
+		Minute:                   minuteVal,
+		Hour:                     hourVal,
+		DayOfMonth:               domVal,
+		Month:                    monthVal,
+		DayOfWeek:                dowVal,
+		Year:                     year,
+		Command:                  next(),
+		Location:                 loc,
+		domStar:                  domRaw == "*",
+		dowStar:                  dowRaw == "*",
+		DayOfMonthQuestion:       domQuestion,
+		DayOfMonthLast:           domLast,
+		DayOfMonthNearestWeekday: domNearest,
+		DayOfWeekQuestion:        dowQuestion,
+		DayOfWeekLast:            dowLast,
+		DayOfWeekNth:             dowNth,
+	}, nil
+}
+
+// parseDayOfMonthField parses a single DayOfMonth field under
+// ParserOptions, recognizing "?", "L" and "<n>W" in addition to the normal
+// expand syntax.
+func parseDayOfMonthField(s string) (values []uint8, last bool, nearestWeekday uint8, question bool, err error) {
+	switch {
+	case s == "?":
+		question = true
+	case s == "L":
+		last = true
+	case strings.HasSuffix(s, "W"):
+		n, aerr := atoi(strings.TrimSuffix(s, "W"))
+		if aerr != nil || n < 1 || n > 31 {
+			err = fmt.Errorf("invalid nearest-weekday value: %v", s)
+			return
+		}
+		nearestWeekday = n
+	default:
+		values, err = expand(s, dayOfMonth, 1, 31)
+	}
+	return
+}
+
+// parseDayOfWeekField parses a single DayOfWeek field under ParserOptions,
+// recognizing "?", "<dow>L" and "<dow>#<n>" in addition to the normal
+// expand syntax.
+func parseDayOfWeekField(s string) (values []uint8, last uint8, nth struct{ Day, N uint8 }, question bool, err error) {
+	switch {
+	case s == "?":
+		question = true
+	case strings.HasSuffix(s, "L"):
+		d, derr := expandSingle(strings.TrimSuffix(s, "L"), dayOfWeek, 0, 7)
+		if derr != nil {
+			err = fmt.Errorf("invalid last-weekday value: %v", s)
+			return
+		}
+		last = d[0]
+	case strings.ContainsRune(s, '#'):
+		hp := strings.SplitN(s, "#", 2)
+		if len(hp) != 2 {
+			err = fmt.Errorf("invalid nth-weekday value: %v", s)
+			return
+		}
+		d, derr := expandSingle(hp[0], dayOfWeek, 0, 7)
+		if derr != nil {
+			err = fmt.Errorf("invalid nth-weekday value: %v", s)
+			return
+		}
+		n, nerr := atoi(hp[1])
+		if nerr != nil || n < 1 || n > 5 {
+			err = fmt.Errorf("invalid nth-weekday count: %v", hp[1])
+			return
+		}
+		nth = struct{ Day, N uint8 }{Day: d[0], N: n}
+	default:
+		values, err = expand(s, dayOfWeek, 0, 7)
+	}
+	return
+}
+
+// expandYear parses the optional trailing year field: "*" (any year, which
+// expandYear reports as a nil slice), a single year, or a comma-separated
+// list of years and year ranges.
+func expandYear(s string) ([]uint16, error) {
+	if s == "*" {
+		return nil, nil
+	}
+
+	var ret []uint16
+	for _, part := range strings.Split(s, ",") {
+		if strings.ContainsRune(part, '-') {
+			bounds := strings.SplitN(part, "-", 2)
+			if len(bounds) != 2 {
+				return nil, fmt.Errorf("invalid range: %v", part)
+			}
+			start, err := strconv.ParseUint(bounds[0], 10, 16)
+			if err != nil {
+				return nil, fmt.Errorf("invalid range start: %v", bounds[0])
+			}
+			end, err := strconv.ParseUint(bounds[1], 10, 16)
+			if err != nil {
+				return nil, fmt.Errorf("invalid range end: %v", bounds[1])
+			}
+			if start > end {
+				return nil, fmt.Errorf("invalid range %d > %d", start, end)
+			}
+			for y := start; y <= end; y++ {
+				ret = append(ret, uint16(y))
+			}
+			continue
+		}
+
+		y, err := strconv.ParseUint(part, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value: %v", part)
+		}
+		ret = append(ret, uint16(y))
+	}
+	return ret, nil
+}
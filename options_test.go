@@ -0,0 +1,150 @@
+package cron
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseWithOptionsSeconds(t *testing.T) {
+	cases := []struct {
+		in   string
+		opts ParserOptions
+		want Expression
+		err  string
+	}{
+		{
+			in:   "0 0 0 1 1 1-5 2024-2026 /bin/test",
+			opts: ParserOptions{Seconds: true, Year: true},
+			want: Expression{
+				Second:     []uint8{0},
+				Minute:     []uint8{0},
+				Hour:       []uint8{0},
+				DayOfMonth: []uint8{1},
+				Month:      []uint8{1},
+				DayOfWeek:  []uint8{1, 2, 3, 4, 5},
+				Year:       []uint16{2024, 2025, 2026},
+				Command:    "/bin/test",
+			},
+		},
+		{
+			in:   "30 0 0 1 1 * * /bin/test",
+			opts: ParserOptions{Seconds: true, Year: true},
+			want: Expression{
+				Second:     []uint8{30},
+				Minute:     []uint8{0},
+				Hour:       []uint8{0},
+				DayOfMonth: []uint8{1},
+				Month:      []uint8{1},
+				DayOfWeek:  []uint8{1, 2, 3, 4, 5, 6, 7},
+				Year:       nil,
+				Command:    "/bin/test",
+			},
+		},
+		{
+			in:   "60 0 0 1 1 * /bin/test",
+			opts: ParserOptions{Seconds: true},
+			err:  "outside of range: 0-59",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := ParseWithOptions(tc.in, tc.opts)
+			if tc.err == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.err != "" {
+				if err == nil {
+					t.Fatal("expected error")
+				} else if !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("err got: %v, want %v", err, tc.err)
+				}
+				return
+			}
+			if diff := cmp.Diff(got.Second, tc.want.Second); diff != "" {
+				t.Errorf("Second (-got +want)\n:%s", diff)
+			}
+			if diff := cmp.Diff(got.Year, tc.want.Year); diff != "" {
+				t.Errorf("Year (-got +want)\n:%s", diff)
+			}
+			if diff := cmp.Diff(got.String(), tc.want.String()); diff != "" {
+				t.Errorf("(-got +want)\n:%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseWithOptionsQuartzSpecials(t *testing.T) {
+	cases := []struct {
+		in   string
+		want Expression
+		err  string
+	}{
+		{
+			in: "0 0 ? 1 MON /bin/test",
+			want: Expression{
+				DayOfMonthQuestion: true,
+				DayOfWeekLast:      0,
+			},
+		},
+		{
+			in: "0 0 L * ? /bin/test",
+			want: Expression{
+				DayOfMonthLast:    true,
+				DayOfWeekQuestion: true,
+			},
+		},
+		{
+			in: "0 0 15W * ? /bin/test",
+			want: Expression{
+				DayOfMonthNearestWeekday: 15,
+				DayOfWeekQuestion:        true,
+			},
+		},
+		{
+			in: "0 0 ? * 6L /bin/test",
+			want: Expression{
+				DayOfMonthQuestion: true,
+				DayOfWeekLast:      6,
+			},
+		},
+		{
+			in: "0 0 ? * 2#1 /bin/test",
+			want: Expression{
+				DayOfMonthQuestion: true,
+				DayOfWeekNth:       struct{ Day, N uint8 }{Day: 2, N: 1},
+			},
+		},
+		{
+			in:  "0 0 ? * ? /bin/test",
+			err: "cannot both be ?",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := ParseWithOptions(tc.in, ParserOptions{})
+			if tc.err == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.err != "" {
+				if err == nil {
+					t.Fatal("expected error")
+				} else if !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("err got: %v, want %v", err, tc.err)
+				}
+				return
+			}
+			if got.DayOfMonthQuestion != tc.want.DayOfMonthQuestion ||
+				got.DayOfMonthLast != tc.want.DayOfMonthLast ||
+				got.DayOfMonthNearestWeekday != tc.want.DayOfMonthNearestWeekday ||
+				got.DayOfWeekQuestion != tc.want.DayOfWeekQuestion ||
+				got.DayOfWeekLast != tc.want.DayOfWeekLast ||
+				got.DayOfWeekNth != tc.want.DayOfWeekNth {
+				t.Errorf("got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
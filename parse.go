@@ -31,6 +31,7 @@ import (
 	"strconv"
 	"strings"
 	"text/tabwriter"
+	"time"
 )
 
 const (
@@ -86,10 +87,80 @@ type Expression struct {
 	Month      []uint8
 	DayOfWeek  []uint8
 	Command    string
+
+	// Location is the time zone Next and NextN evaluate the schedule in.
+	// It is time.Local unless set via ParseInLocation or
+	// ParseInLocationWithOptions.
+	Location *time.Location
+
+	// domStar and dowStar record whether DayOfMonth/DayOfWeek were left
+	// unrestricted ("*") in the source expression, which controls the
+	// traditional cron OR-semantics between the two fields: when both are
+	// restricted, a match on either field is enough.
+	domStar, dowStar bool
+
+	// Every is set by the "@every <duration>" macro. When non-zero, the
+	// expression fires on a fixed interval rather than by field matching.
+	Every time.Duration
+
+	// RunAtStartup is set by the "@reboot" macro. It has no periodic
+	// schedule; downstream schedulers should run the command once on
+	// startup instead of calling Next.
+	RunAtStartup bool
+
+	// Second and Year are only populated by ParseWithOptions /
+	// ParseInLocationWithOptions, and only when ParserOptions.Seconds or
+	// ParserOptions.Year is set. Year is nil when the field was "*".
+	Second []uint8
+	Year   []uint16
+
+	// The following fields hold the Quartz-style special characters
+	// (?, L, W, #) that ParseWithOptions accepts on DayOfMonth/DayOfWeek.
+	// They can't be pre-expanded into DayOfMonth/DayOfWeek, so they're
+	// recorded here instead; Next does not consult them. At most one of
+	// DayOfMonthQuestion/DayOfWeekQuestion is ever true.
+	DayOfMonthQuestion       bool
+	DayOfMonthLast           bool  // DayOfMonth is "L": the last day of the month
+	DayOfMonthNearestWeekday uint8 // set by "<n>W": the weekday nearest day n
+	DayOfWeekQuestion        bool
+	DayOfWeekLast            uint8 // set by "<dow>L": the last <dow> of the month
+	DayOfWeekNth             struct {
+		Day, N uint8 // set by "<dow>#<n>": the n-th <dow> of the month
+	}
+}
+
+// macros maps the standard @-prefixed shorthands to their equivalent 5-field
+// expressions.
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
 }
 
-// Parse cron string s into Expression.
+// Parse cron string s into Expression. The returned Expression is evaluated
+// in time.Local; use ParseInLocation to evaluate it elsewhere.
 func Parse(s string) (Expression, error) {
+	return ParseInLocation(s, time.Local)
+}
+
+// ParseInLocation is like Parse but evaluates the resulting Expression's
+// schedule in loc.
+//
+// In addition to the 5-field form, s may start with one of the standard
+// macros (@yearly, @annually, @monthly, @weekly, @daily, @midnight, @hourly),
+// which is substituted for its equivalent expression, "@reboot", which sets
+// RunAtStartup instead of a schedule, or "@every <duration>", where duration
+// is parsed with time.ParseDuration and sets Every. In every case, whatever
+// follows the macro is still captured in Command.
+func ParseInLocation(s string, loc *time.Location) (Expression, error) {
+	if strings.HasPrefix(s, "@") {
+		return parseMacro(s, loc)
+	}
+
 	parts := strings.SplitN(s, " ", 6)
 	if len(parts) != 6 {
 		return Expression{}, errors.New("invalid expression")
@@ -112,9 +183,44 @@ func Parse(s string) (Expression, error) {
 		Month:      expanded[month],
 		DayOfWeek:  expanded[dayOfWeek],
 		Command:    parts[command],
+		Location:   loc,
+		domStar:    parts[dayOfMonth] == "*",
+		dowStar:    parts[dayOfWeek] == "*",
 	}, nil
 }
 
+// parseMacro parses an expression starting with "@" and returns the
+// resulting Expression. See ParseInLocation for the supported macros.
+func parseMacro(s string, loc *time.Location) (Expression, error) {
+	parts := strings.SplitN(s, " ", 2)
+	name, rest := parts[0], ""
+	if len(parts) == 2 {
+		rest = parts[1]
+	}
+
+	switch name {
+	case "@reboot":
+		return Expression{Command: rest, RunAtStartup: true, Location: loc}, nil
+	case "@every":
+		durParts := strings.SplitN(rest, " ", 2)
+		d, err := time.ParseDuration(durParts[0])
+		if err != nil {
+			return Expression{}, fmt.Errorf("@every: %w", err)
+		}
+		cmd := ""
+		if len(durParts) == 2 {
+			cmd = durParts[1]
+		}
+		return Expression{Command: cmd, Every: d, Location: loc}, nil
+	}
+
+	expr, ok := macros[name]
+	if !ok {
+		return Expression{}, fmt.Errorf("unknown macro: %v", name)
+	}
+	return ParseInLocation(expr+" "+rest, loc)
+}
+
 // String returns a pretty printed table of the receiver.
 func (e Expression) String() string {
 	var b strings.Builder
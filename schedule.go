@@ -0,0 +1,155 @@
+package cron
+
+import "time"
+
+// Next returns the next time strictly after t that the schedule fires,
+// evaluated in e.Location (time.Local if unset). It returns the zero Time if
+// no match occurs within five years of t, which usually indicates an
+// impossible expression such as "0 0 30 2 *".
+//
+// Next is DST-aware: a wall-clock time that doesn't exist because of a
+// spring-forward gap fires once, at the first valid instant after the gap;
+// a wall-clock time that occurs twice because of a fall-back overlap fires
+// exactly once, at the instant produced by Go's own time.Date resolution of
+// that ambiguity.
+//
+// Next matches on Minute, Hour, DayOfMonth, Month and DayOfWeek only and
+// always fires at second 0; it does not consult Second, Year or the
+// Quartz-style day fields that ParseWithOptions sets.
+func (e Expression) Next(t time.Time) time.Time {
+	loc := e.Location
+	if loc == nil {
+		loc = time.Local
+	}
+
+	if e.RunAtStartup {
+		// "@reboot" has no periodic schedule; callers should run it once
+		// on startup instead of calling Next.
+		return time.Time{}
+	}
+	if e.Every > 0 {
+		return t.In(loc).Add(e.Every)
+	}
+
+	t = t.In(loc)
+	from := t
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc).Add(time.Minute)
+	if !t.After(from) {
+		// from is in the second pass of a fall-back hour, which time.Date
+		// resolves to the first pass; step forward from the instant instead.
+		t = from.Truncate(time.Minute).Add(time.Minute)
+	}
+
+	yearLimit := t.Year() + 5
+	for {
+		if t.Year() > yearLimit {
+			return time.Time{}
+		}
+
+		if !contains(e.Month, uint8(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if !e.dayMatches(t) {
+			next := t.AddDate(0, 0, 1)
+			t = time.Date(next.Year(), next.Month(), next.Day(), 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if c, ok := e.nextTimeOfDay(t, loc); ok {
+			return c
+		}
+		next := t.AddDate(0, 0, 1)
+		t = time.Date(next.Year(), next.Month(), next.Day(), 0, 0, 0, 0, loc)
+	}
+}
+
+// NextN returns the next n times the schedule fires after t, in order. It
+// may return fewer than n times if the schedule has no more matches within
+// five years of the last computed time.
+func (e Expression) NextN(t time.Time, n int) []time.Time {
+	ret := make([]time.Time, 0, n)
+	for i := 0; i < n; i++ {
+		t = e.Next(t)
+		if t.IsZero() {
+			break
+		}
+		ret = append(ret, t)
+	}
+	return ret
+}
+
+// dayMatches reports whether t's day-of-month or day-of-week satisfies the
+// expression, applying the traditional cron OR-semantics: if both
+// DayOfMonth and DayOfWeek are restricted (not "*"), a match on either field
+// is sufficient; otherwise both the restricted field(s) must match, which is
+// equivalent to requiring the single restricted field (or, if neither is
+// restricted, every day matches).
+func (e Expression) dayMatches(t time.Time) bool {
+	domOK := contains(e.DayOfMonth, uint8(t.Day()))
+	dowOK := e.dayOfWeekMatches(t)
+	if !e.domStar && !e.dowStar {
+		return domOK || dowOK
+	}
+	return domOK && dowOK
+}
+
+// dayOfWeekMatches reports whether t's weekday is in e.DayOfWeek. Sunday is
+// checked against both its 0 and 7 spellings, since expand represents an
+// unrestricted field as 1-7 but an explicit "0" as {0}.
+func (e Expression) dayOfWeekMatches(t time.Time) bool {
+	w := uint8(t.Weekday())
+	if w == 0 {
+		return contains(e.DayOfWeek, 0) || contains(e.DayOfWeek, 7)
+	}
+	return contains(e.DayOfWeek, w)
+}
+
+// nextTimeOfDay returns the earliest instant on t's calendar day, at or
+// after t, that time.Date gives for an hour in e.Hour and a minute in
+// e.Minute. A wall-clock time in a DST gap is moved past the gap; of a
+// wall-clock time that occurs twice, only time.Date's choice is considered.
+func (e Expression) nextTimeOfDay(t time.Time, loc *time.Location) (time.Time, bool) {
+	y, mo, d := t.Date()
+	var best time.Time
+	found := false
+	for _, h := range e.Hour {
+		for _, m := range e.Minute {
+			c := time.Date(y, mo, d, int(h), int(m), 0, 0, loc)
+			if c.Hour() != int(h) || c.Minute() != int(m) {
+				// h:m fell in a DST gap; advance past it to the first
+				// instant whose wall-clock reading reaches h:m.
+				c = snapPastGap(c, h, m)
+			}
+			if c.Day() != d || c.Month() != mo || c.Before(t) {
+				continue
+			}
+			if !found || c.Before(best) {
+				best, found = c, true
+			}
+		}
+	}
+	return best, found
+}
+
+// snapPastGap advances c, a time whose wall clock was pulled backwards by a
+// DST gap, minute by minute until its wall-clock reading reaches h:m.
+// Because the advance is duration-based it steps over the gap in a single
+// jump rather than revisiting any skipped wall-clock minute.
+func snapPastGap(c time.Time, h, m uint8) time.Time {
+	want := int(h)*60 + int(m)
+	for i := 0; i < 180 && c.Hour()*60+c.Minute() < want; i++ {
+		c = c.Add(time.Minute)
+	}
+	return c
+}
+
+func contains(xs []uint8, v uint8) bool {
+	for _, x := range xs {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
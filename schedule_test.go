@@ -0,0 +1,118 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func mustParseInLocation(t *testing.T, s string, loc *time.Location) Expression {
+	t.Helper()
+	e, err := ParseInLocation(s, loc)
+	if err != nil {
+		t.Fatalf("ParseInLocation(%q): %v", s, err)
+	}
+	return e
+}
+
+func TestExpressionNext(t *testing.T) {
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatalf("LoadLocation: %v", err)
+	}
+
+	cases := []struct {
+		name string
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{
+			name: "simple daily",
+			expr: "30 2 * * * /bin/job",
+			from: time.Date(2023, 3, 10, 3, 0, 0, 0, la),
+			want: time.Date(2023, 3, 11, 2, 30, 0, 0, la),
+		},
+		{
+			// America/Los_Angeles springs forward at 02:00 on 2023-03-12;
+			// 02:30 never happens, so the job should fire once, right
+			// after the gap closes.
+			name: "spring forward gap fires once after the gap",
+			expr: "30 2 * * * /bin/job",
+			from: time.Date(2023, 3, 11, 2, 30, 0, 0, la),
+			want: time.Date(2023, 3, 12, 3, 0, 0, 0, la),
+		},
+		{
+			name: "day after spring forward gap resumes normally",
+			expr: "30 2 * * * /bin/job",
+			from: time.Date(2023, 3, 12, 3, 0, 0, 0, la),
+			want: time.Date(2023, 3, 13, 2, 30, 0, 0, la),
+		},
+		{
+			// America/Los_Angeles falls back at 02:00 on 2023-11-05, so
+			// 01:30 happens twice; the job should fire exactly once.
+			name: "fall back overlap fires exactly once",
+			expr: "30 1 * * * /bin/job",
+			from: time.Date(2023, 11, 4, 3, 0, 0, 0, la),
+			want: time.Date(2023, 11, 5, 1, 30, 0, 0, la),
+		},
+		{
+			name: "day after fall back overlap resumes normally",
+			expr: "30 1 * * * /bin/job",
+			from: time.Date(2023, 11, 5, 1, 30, 0, 0, la),
+			want: time.Date(2023, 11, 6, 1, 30, 0, 0, la),
+		},
+		{
+			// from is 01:30 in the second pass of the repeated hour; the
+			// 01:45 of the first pass is already behind it.
+			name: "from inside second pass of fall back overlap",
+			expr: "45 1 * * * /bin/job",
+			from: time.Date(2023, 11, 5, 1, 30, 0, 0, la).Add(time.Hour),
+			want: time.Date(2023, 11, 6, 1, 45, 0, 0, la),
+		},
+		{
+			name: "dom and dow both restricted use OR semantics",
+			expr: "0 0 1 * 0 /bin/job",
+			from: time.Date(2023, 7, 1, 0, 0, 0, 0, la),
+			want: time.Date(2023, 7, 2, 0, 0, 0, 0, la), // next Sunday, not the 1st
+		},
+		{
+			name: "impossible expression has no match",
+			expr: "0 0 30 2 * /bin/job",
+			from: time.Date(2023, 1, 1, 0, 0, 0, 0, la),
+			want: time.Time{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := mustParseInLocation(t, tc.expr, la)
+			got := e.Next(tc.from)
+			if !got.Equal(tc.want) {
+				t.Errorf("Next(%v) = %v, want %v", tc.from, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestExpressionNextNFallBackFiresOncePerMinute(t *testing.T) {
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatalf("LoadLocation: %v", err)
+	}
+	e := mustParseInLocation(t, "* 1 * * * /bin/job", la)
+
+	from := time.Date(2023, 11, 5, 0, 30, 0, 0, la)
+	got := e.NextN(from, 61)
+	if len(got) != 61 {
+		t.Fatalf("got %d fires, want 60 through the fall-back hour and 1 after", len(got))
+	}
+	for _, ti := range got[:60] {
+		if ti.Day() != 5 || ti.Month() != time.November {
+			t.Fatalf("fire %v outside the fall-back day", ti)
+		}
+	}
+	// The repeated 01:xx minutes of the second pass don't fire again.
+	if want := time.Date(2023, 11, 6, 1, 0, 0, 0, la); !got[60].Equal(want) {
+		t.Fatalf("fire after the fall-back hour = %v, want %v", got[60], want)
+	}
+}
@@ -0,0 +1,293 @@
+package cron
+
+import (
+	"container/heap"
+	"context"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// forever is how long the run loop's timer waits when there's nothing
+// scheduled; AddJob, Remove and Stop still interrupt the wait through the
+// wake channel and the run loop's context.
+const forever = 100000 * time.Hour
+
+// JobID identifies an entry added to a Scheduler.
+type JobID int64
+
+// Entry is a snapshot of one Scheduler entry, as returned by Entries.
+type Entry struct {
+	ID   JobID
+	Next time.Time
+	Prev time.Time
+}
+
+// entry is a Scheduler's internal bookkeeping for one registered job; index
+// is maintained by the entryHeap for container/heap.
+type entry struct {
+	id    JobID
+	expr  Expression
+	job   Job
+	next  time.Time
+	prev  time.Time
+	index int
+}
+
+// entryHeap is a min-heap of entries ordered by next fire time. Entries
+// that never fire again (Next is the zero Time, e.g. "@reboot") sort last.
+type entryHeap []*entry
+
+func (h entryHeap) Len() int { return len(h) }
+
+func (h entryHeap) Less(i, j int) bool {
+	if h[i].next.IsZero() {
+		return false
+	}
+	if h[j].next.IsZero() {
+		return true
+	}
+	return h[i].next.Before(h[j].next)
+}
+
+func (h entryHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index, h[j].index = i, j
+}
+
+func (h *entryHeap) Push(x interface{}) {
+	e := x.(*entry)
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *entryHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	e.index = -1
+	*h = old[:n-1]
+	return e
+}
+
+// Scheduler runs jobs parsed as cron Expressions. A single goroutine drives
+// a min-heap of upcoming fire times, so it scales to many entries without a
+// timer per job. Use NewScheduler to construct one.
+type Scheduler struct {
+	mu      sync.Mutex
+	entries entryHeap
+	byID    map[JobID]*entry
+	nextID  JobID
+	logger  Logger
+	wake    chan struct{}
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// SchedulerOption configures a Scheduler constructed by NewScheduler.
+type SchedulerOption func(*Scheduler)
+
+// WithLogger sets the Logger a Scheduler uses to report recovered job
+// panics. The default is a DefaultLogger writing to os.Stderr.
+func WithLogger(l Logger) SchedulerOption {
+	return func(s *Scheduler) { s.logger = l }
+}
+
+// NewScheduler constructs a Scheduler. Call AddFunc/AddJob to register
+// entries, then Start to begin running them.
+func NewScheduler(opts ...SchedulerOption) *Scheduler {
+	s := &Scheduler{
+		byID:   make(map[JobID]*entry),
+		logger: DefaultLogger{log.New(os.Stderr, "cron: ", log.LstdFlags)},
+		wake:   make(chan struct{}, 1),
+	}
+	for _, o := range opts {
+		o(s)
+	}
+	return s
+}
+
+// AddFunc registers fn to run on expr's schedule, wrapping it with any
+// JobWrappers in opts (after the Scheduler's own panic recovery). It
+// returns the JobID to later pass to Remove.
+func (s *Scheduler) AddFunc(expr string, fn func(context.Context), opts ...JobWrapper) (JobID, error) {
+	return s.AddJob(expr, JobFunc(fn), opts...)
+}
+
+// AddJob registers j to run on expr's schedule, wrapping it with any
+// JobWrappers in opts (after the Scheduler's own panic recovery). It
+// returns the JobID to later pass to Remove.
+func (s *Scheduler) AddJob(expr string, j Job, opts ...JobWrapper) (JobID, error) {
+	e, err := Parse(expr)
+	if err != nil {
+		return 0, err
+	}
+	return s.addExpression(e, j, opts...)
+}
+
+// addExpression is AddJob for callers, such as the crontab loader, that
+// have already parsed an Expression and don't want to re-parse it from a
+// string.
+func (s *Scheduler) addExpression(e Expression, j Job, opts ...JobWrapper) (JobID, error) {
+	wrappers := append([]JobWrapper{Recover(s.logger)}, opts...)
+	wrapped := NewChain(wrappers...).Then(j)
+
+	now := time.Now()
+	if e.Location != nil {
+		now = now.In(e.Location)
+	}
+
+	s.mu.Lock()
+	s.nextID++
+	id := s.nextID
+	ent := &entry{id: id, expr: e, job: wrapped, next: e.Next(now)}
+	s.byID[id] = ent
+	heap.Push(&s.entries, ent)
+	s.mu.Unlock()
+
+	s.wakeup()
+	return id, nil
+}
+
+// Remove unregisters the entry with the given JobID. It's a no-op if id is
+// unknown, e.g. because it was already removed.
+func (s *Scheduler) Remove(id JobID) {
+	s.mu.Lock()
+	e, ok := s.byID[id]
+	if ok {
+		delete(s.byID, id)
+		heap.Remove(&s.entries, e.index)
+	}
+	s.mu.Unlock()
+
+	if ok {
+		s.wakeup()
+	}
+}
+
+// Entries returns a snapshot of all registered entries, soonest Next
+// first.
+func (s *Scheduler) Entries() []Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ret := make([]Entry, 0, len(s.entries))
+	for _, e := range s.entries {
+		ret = append(ret, Entry{ID: e.id, Next: e.next, Prev: e.prev})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Next.IsZero() {
+			return false
+		}
+		if ret[j].Next.IsZero() {
+			return true
+		}
+		return ret[i].Next.Before(ret[j].Next)
+	})
+	return ret
+}
+
+// Start runs entries with RunAtStartup set (i.e. parsed from "@reboot")
+// once, then starts the goroutine that fires the rest on their schedules.
+// Start returns immediately; call Stop to shut the Scheduler down. Start is
+// a no-op if the Scheduler is already running.
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	if s.cancel != nil {
+		s.mu.Unlock()
+		return
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	s.cancel = cancel
+	s.done = make(chan struct{})
+
+	var startup []Job
+	for _, e := range s.entries {
+		if e.expr.RunAtStartup {
+			startup = append(startup, e.job)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, j := range startup {
+		go j.Run(ctx)
+	}
+	go s.run(ctx)
+}
+
+// Stop signals the run loop to exit and waits for it to do so. Entries
+// already running are not waited on, but the context they were given (the
+// one derived from Start's) is canceled. Stop is a no-op if the Scheduler
+// isn't running.
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	cancel := s.cancel
+	done := s.done
+	s.cancel = nil
+	s.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+func (s *Scheduler) run(ctx context.Context) {
+	defer close(s.done)
+	for {
+		timer := time.NewTimer(s.nextWait())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-s.wake:
+			timer.Stop()
+		case now := <-timer.C:
+			s.fire(ctx, now)
+		}
+	}
+}
+
+func (s *Scheduler) nextWait() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.entries.Len() == 0 || s.entries[0].next.IsZero() {
+		return forever
+	}
+	return time.Until(s.entries[0].next)
+}
+
+// fire pops and reschedules every entry due at or before now, then runs
+// each in its own goroutine.
+func (s *Scheduler) fire(ctx context.Context, now time.Time) {
+	s.mu.Lock()
+	var due []*entry
+	for s.entries.Len() > 0 {
+		e := s.entries[0]
+		if e.next.IsZero() || e.next.After(now) {
+			break
+		}
+		due = append(due, heap.Pop(&s.entries).(*entry))
+	}
+	for _, e := range due {
+		e.prev = e.next
+		e.next = e.expr.Next(now)
+		heap.Push(&s.entries, e)
+	}
+	s.mu.Unlock()
+
+	for _, e := range due {
+		go e.job.Run(ctx)
+	}
+}
+
+func (s *Scheduler) wakeup() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
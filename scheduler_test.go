@@ -0,0 +1,107 @@
+package cron
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSchedulerFiresOnSchedule(t *testing.T) {
+	s := NewScheduler()
+	var count int32
+	id, err := s.AddFunc("@every 20ms", func(ctx context.Context) {
+		atomic.AddInt32(&count, 1)
+	})
+	if err != nil {
+		t.Fatalf("AddFunc: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.Start(ctx)
+	time.Sleep(110 * time.Millisecond)
+	s.Stop()
+
+	if n := atomic.LoadInt32(&count); n < 3 {
+		t.Fatalf("got %d fires in 110ms of a 20ms schedule, want at least 3", n)
+	}
+
+	s.Remove(id)
+	if got := s.Entries(); len(got) != 0 {
+		t.Fatalf("Entries() = %v, want none after Remove", got)
+	}
+}
+
+func TestSchedulerSkipIfStillRunning(t *testing.T) {
+	s := NewScheduler()
+	var concurrent, maxConcurrent int32
+	_, err := s.AddFunc("@every 10ms", func(ctx context.Context) {
+		c := atomic.AddInt32(&concurrent, 1)
+		for {
+			old := atomic.LoadInt32(&maxConcurrent)
+			if c <= old || atomic.CompareAndSwapInt32(&maxConcurrent, old, c) {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+		atomic.AddInt32(&concurrent, -1)
+	}, WithSkipIfStillRunning())
+	if err != nil {
+		t.Fatalf("AddFunc: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.Start(ctx)
+	time.Sleep(120 * time.Millisecond)
+	s.Stop()
+
+	if n := atomic.LoadInt32(&maxConcurrent); n > 1 {
+		t.Fatalf("max concurrent invocations = %d, want at most 1 with WithSkipIfStillRunning", n)
+	}
+}
+
+func TestSchedulerRunAtStartup(t *testing.T) {
+	s := NewScheduler()
+	done := make(chan struct{})
+	_, err := s.AddFunc("@reboot", func(ctx context.Context) {
+		close(done)
+	})
+	if err != nil {
+		t.Fatalf("AddFunc: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.Start(ctx)
+	defer s.Stop()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for @reboot job to run on Start")
+	}
+}
+
+func TestSchedulerRecoversPanics(t *testing.T) {
+	s := NewScheduler()
+	var fired int32
+	_, err := s.AddFunc("@every 10ms", func(ctx context.Context) {
+		atomic.AddInt32(&fired, 1)
+		panic("boom")
+	})
+	if err != nil {
+		t.Fatalf("AddFunc: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.Start(ctx)
+	time.Sleep(50 * time.Millisecond)
+	s.Stop()
+
+	if n := atomic.LoadInt32(&fired); n < 2 {
+		t.Fatalf("got %d fires, want the scheduler to keep running across panics", n)
+	}
+}